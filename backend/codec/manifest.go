@@ -0,0 +1,77 @@
+package codec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateHlsMaster builds a master playlist listing one variant stream per rendition.
+func GenerateHlsMaster(ladder []Rendition) string {
+	var sb strings.Builder
+
+	sb.WriteString("#EXTM3U\n")
+	sb.WriteString("#EXT-X-VERSION:7\n")
+
+	for _, rendition := range ladder {
+		bandwidth := (rendition.VideoBitrate + rendition.AudioBitrate) * 1000
+		sb.WriteString(fmt.Sprintf(
+			"#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d\n",
+			bandwidth, rendition.Width, rendition.Height,
+		))
+		sb.WriteString(fmt.Sprintf("%s/variant.m3u8\n", rendition.Name))
+	}
+
+	return sb.String()
+}
+
+// GenerateHlsVariant builds a media playlist referencing the fMP4 segments produced by Transcode.
+func GenerateHlsVariant(segmentCount int) string {
+	var sb strings.Builder
+
+	sb.WriteString("#EXTM3U\n")
+	sb.WriteString("#EXT-X-VERSION:7\n")
+	sb.WriteString(fmt.Sprintf("#EXT-X-TARGETDURATION:%d\n", segmentDurationSecs))
+	sb.WriteString("#EXT-X-PLAYLIST-TYPE:VOD\n")
+	sb.WriteString("#EXT-X-MAP:URI=\"init.mp4\"\n")
+
+	for i := 0; i < segmentCount; i++ {
+		sb.WriteString(fmt.Sprintf("#EXTINF:%d.0,\n", segmentDurationSecs))
+		sb.WriteString(fmt.Sprintf("segment-%05d.m4s\n", i))
+	}
+
+	sb.WriteString("#EXT-X-ENDLIST\n")
+
+	return sb.String()
+}
+
+// GenerateDashManifest builds an MPD with one AdaptationSet per rendition, each containing a
+// single Representation, matching the segments produced by Transcode.
+func GenerateDashManifest(ladder []Rendition, durationSecs int32) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	sb.WriteString(fmt.Sprintf(
+		`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" mediaPresentationDuration="PT%dS" profiles="urn:mpeg:dash:profile:isoff-on-demand:2011">`+"\n",
+		durationSecs,
+	))
+	sb.WriteString("  <Period>\n")
+
+	for _, rendition := range ladder {
+		sb.WriteString(fmt.Sprintf("    <AdaptationSet segmentAlignment=\"true\" mimeType=\"video/mp4\">\n"))
+		sb.WriteString(fmt.Sprintf(
+			"      <Representation id=\"%s\" bandwidth=\"%d\" width=\"%d\" height=\"%d\" codecs=\"avc1.640028,mp4a.40.2\">\n",
+			rendition.Name, (rendition.VideoBitrate+rendition.AudioBitrate)*1000, rendition.Width, rendition.Height,
+		))
+		sb.WriteString(fmt.Sprintf(
+			"        <SegmentTemplate initialization=\"%s/init.mp4\" media=\"%s/segment-$Number%%05d$.m4s\" duration=\"%d\" startNumber=\"0\"/>\n",
+			rendition.Name, rendition.Name, segmentDurationSecs,
+		))
+		sb.WriteString("      </Representation>\n")
+		sb.WriteString("    </AdaptationSet>\n")
+	}
+
+	sb.WriteString("  </Period>\n")
+	sb.WriteString("</MPD>\n")
+
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+package codec
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Rendition describes one entry in an adaptive bitrate ladder.
+type Rendition struct {
+	Name         string
+	Width        int
+	Height       int
+	VideoBitrate int // kbps
+	AudioBitrate int // kbps
+}
+
+var DefaultLadder = []Rendition{
+	{Name: "240p", Width: 426, Height: 240, VideoBitrate: 400, AudioBitrate: 64},
+	{Name: "480p", Width: 854, Height: 480, VideoBitrate: 1000, AudioBitrate: 128},
+	{Name: "720p", Width: 1280, Height: 720, VideoBitrate: 2500, AudioBitrate: 128},
+	{Name: "1080p", Width: 1920, Height: 1080, VideoBitrate: 5000, AudioBitrate: 192},
+}
+
+const segmentDurationSecs = 4
+
+// RenditionResult carries the outcome of transcoding a single ladder entry, so that one
+// rendition's ffmpeg failure doesn't discard the segment counts of the renditions that already
+// succeeded.
+type RenditionResult struct {
+	Name         string
+	SegmentCount int
+	Err          error
+}
+
+// Transcode produces a fragmented-MP4 rendition of videoDir/video for each entry in ladder,
+// segmented for HLS/DASH delivery, under videoDir/renditions/{name}/. Every entry in ladder gets
+// its own RenditionResult regardless of whether earlier entries failed, so callers can mark each
+// rendition row complete or failed independently.
+func Transcode(videoDir string, ladder []Rendition) []RenditionResult {
+	source := fmt.Sprintf("%s/video", videoDir)
+	results := make([]RenditionResult, len(ladder))
+
+	for i, rendition := range ladder {
+		segmentCount, err := transcodeRendition(source, videoDir, rendition)
+		results[i] = RenditionResult{Name: rendition.Name, SegmentCount: segmentCount, Err: err}
+	}
+
+	return results
+}
+
+func transcodeRendition(source string, videoDir string, rendition Rendition) (int, error) {
+	renditionDir := fmt.Sprintf("%s/renditions/%s", videoDir, rendition.Name)
+
+	if err := os.MkdirAll(renditionDir, os.ModePerm); err != nil {
+		return 0, err
+	}
+
+	cmd := exec.Command(
+		"ffmpeg",
+		"-y",
+		"-i", source,
+		"-vf", fmt.Sprintf("scale=%d:%d", rendition.Width, rendition.Height),
+		"-c:v", "libx264",
+		"-b:v", fmt.Sprintf("%dk", rendition.VideoBitrate),
+		"-c:a", "aac",
+		"-b:a", fmt.Sprintf("%dk", rendition.AudioBitrate),
+		"-f", "dash",
+		"-seg_duration", fmt.Sprintf("%d", segmentDurationSecs),
+		"-use_template", "1",
+		"-use_timeline", "1",
+		"-init_seg_name", "init.mp4",
+		"-media_seg_name", "segment-$Number%05d$.m4s",
+		fmt.Sprintf("%s/stream.mpd", renditionDir),
+	)
+
+	if err := cmd.Run(); err != nil {
+		return 0, err
+	}
+
+	return countSegments(renditionDir)
+}
+
+func countSegments(renditionDir string) (int, error) {
+	matches, err := filepath.Glob(fmt.Sprintf("%s/segment-*.m4s", renditionDir))
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(matches), nil
+}
+
+func SegmentPath(videoDir string, rendition string, segment string) string {
+	return fmt.Sprintf("%s/renditions/%s/%s", videoDir, rendition, segment)
+}
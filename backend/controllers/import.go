@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"streamfox-backend/codec"
+	"streamfox-backend/importer"
+	"streamfox-backend/models"
+	"streamfox-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errImportUnsupportedScheme = errors.New("source url must use http or https")
+
+type ImportRequest struct {
+	SourceUrl string `json:"source_url" binding:"required,url"`
+}
+
+type ImportCreatedInfo struct {
+	Id string `json:"id"`
+}
+
+func ImportVideo(c *gin.Context) {
+	var request ImportRequest
+
+	if ok := checkValidationError(c, c.ShouldBindJSON(&request)); !ok {
+		return
+	}
+
+	if ok := checkUserError(c, importer.ValidateSourceUrl(request.SourceUrl), errImportUnsupportedScheme); !ok {
+		return
+	}
+
+	user := getUserParam(c)
+	video, err := models.NewVideo(user)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	video.Status = models.IMPORTING
+	err = video.Save()
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	videoImport := &models.VideoImport{
+		VideoId:       video.Id,
+		SourceUrl:     request.SourceUrl,
+		State:         models.IMPORT_PENDING,
+		LastAttemptAt: time.Now(),
+	}
+
+	err = models.SaveImport(videoImport)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	runImport(video, videoImport)
+
+	c.JSON(http.StatusCreated, ImportCreatedInfo{Id: video.IdSnowflake().Base58()})
+}
+
+// runImport drives one attempt of the import worker in the background: fetch metadata, download
+// the media, then hand off to the existing probe/thumbnail (and, if present, transcode)
+// pipeline. Failures of the metadata, download, probe and thumbnail steps are recorded on the
+// VideoImport row and retried with exponential backoff, up to maxImportRetries times. A failure
+// to persist progress is only logged and ends the attempt without a retry.
+func runImport(video *models.Video, videoImport *models.VideoImport) {
+	go func() {
+		dataRoot := utils.GetEnvVar(utils.DATA_ROOT)
+		videoDir := fmt.Sprintf("%s/videos/%s", dataRoot, video.IdSnowflake().Base58())
+
+		if err := os.MkdirAll(videoDir, os.ModePerm); err != nil {
+			failImport(video, videoImport, err)
+			return
+		}
+
+		videoImport.State = models.IMPORT_FETCHING_METADATA
+		videoImport.LastAttemptAt = time.Now()
+		if err := models.SaveImport(videoImport); err != nil {
+			log.Println("could not persist import progress:", err)
+			return
+		}
+
+		metadata, err := importer.FetchMetadata(videoImport.SourceUrl)
+
+		if err != nil {
+			failImport(video, videoImport, err)
+			return
+		}
+
+		video.Name = metadata.Title
+		video.Description = metadata.Description
+
+		videoImport.State = models.IMPORT_DOWNLOADING
+		if err := models.SaveImport(videoImport); err != nil {
+			log.Println("could not persist import progress:", err)
+			return
+		}
+
+		if err := importer.Download(videoImport.SourceUrl, videoDir); err != nil {
+			failImport(video, videoImport, err)
+			return
+		}
+
+		filepath := videoDir + "/video"
+
+		probe, err := codec.Probe(filepath)
+		if err != nil {
+			failImport(video, videoImport, err)
+			return
+		}
+
+		info, err := os.Stat(filepath)
+		if err != nil {
+			failImport(video, videoImport, err)
+			return
+		}
+
+		video.MimeType = probe.MimeType
+		video.DurationSecs = probe.DurationSecs
+		video.SizeBytes = info.Size()
+		video.Status = models.PROCESSING
+
+		if err := codec.GenerateThumbnail(videoDir); err != nil {
+			failImport(video, videoImport, err)
+			return
+		}
+
+		video.Status = models.TRANSCODING
+		if err := video.Save(); err != nil {
+			log.Println("could not persist video after import download:", err)
+			return
+		}
+
+		videoImport.State = models.IMPORT_COMPLETE
+		if err := models.SaveImport(videoImport); err != nil {
+			log.Println("could not persist import completion:", err)
+		}
+
+		beginTranscode(video, videoDir)
+	}()
+}
+
+// maxImportRetries caps how many times a failed import is retried before it's left in a
+// terminal failed state - otherwise a permanently dead source (deleted video, unsupported site)
+// would retry with exponential backoff forever.
+const maxImportRetries = 5
+
+func failImport(video *models.Video, videoImport *models.VideoImport, cause error) {
+	videoImport.State = models.IMPORT_FAILED
+	videoImport.ErrorMessage = cause.Error()
+	videoImport.RetryCount++
+
+	if err := models.SaveImport(videoImport); err != nil {
+		log.Println("could not persist import failure:", err)
+		return
+	}
+
+	if videoImport.RetryCount > maxImportRetries {
+		log.Printf("import of video %s failed permanently after %d attempts: %v", video.IdSnowflake().Base58(), videoImport.RetryCount, cause)
+		return
+	}
+
+	delay := importer.NextRetryDelay(videoImport.RetryCount)
+	log.Printf("import of video %s failed, retrying in %s: %v", video.IdSnowflake().Base58(), delay, cause)
+
+	time.AfterFunc(delay, func() {
+		videoImport.State = models.IMPORT_PENDING
+		videoImport.LastAttemptAt = time.Now()
+		runImport(video, videoImport)
+	})
+}
+
+type ImportStatusInfo struct {
+	SourceUrl     string             `json:"source_url"`
+	State         models.ImportState `json:"state"`
+	ErrorMessage  string             `json:"error_message"`
+	RetryCount    int32              `json:"retry_count"`
+	LastAttemptAt time.Time          `json:"last_attempt_at"`
+}
+
+func GetImportStatus(c *gin.Context) {
+	video := getVideoParam(c)
+
+	videoImport, err := models.FetchImport(video.Id)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, ImportStatusInfo{
+		SourceUrl:     videoImport.SourceUrl,
+		State:         videoImport.State,
+		ErrorMessage:  videoImport.ErrorMessage,
+		RetryCount:    videoImport.RetryCount,
+		LastAttemptAt: videoImport.LastAttemptAt,
+	})
+}
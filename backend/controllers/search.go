@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"streamfox-backend/models"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errSearchInvalidCategory = errors.New("category is not a recognised value")
+	errSearchInvalidSort     = errors.New("sort is not a recognised value")
+)
+
+type SearchQuery struct {
+	Query       string `form:"q"`
+	Category    *int32 `form:"category"`
+	Tag         string `form:"tag"`
+	Creator     string `form:"creator"`
+	MinDuration int32  `form:"min_duration"`
+	MaxDuration int32  `form:"max_duration"`
+	Sort        string `form:"sort"`
+	Before      string `form:"before"`
+}
+
+func (query SearchQuery) toOptions(c *gin.Context) (models.SearchOptions, bool) {
+	opts := models.SearchOptions{
+		Query:           query.Query,
+		Tag:             query.Tag,
+		MinDurationSecs: query.MinDuration,
+		MaxDurationSecs: query.MaxDuration,
+		Sort:            models.SearchSort(query.Sort),
+	}
+
+	if opts.Sort == "" {
+		opts.Sort = models.SORT_RECENT
+	} else if !opts.Sort.Valid() {
+		userError(c, errSearchInvalidSort)
+		return opts, false
+	}
+
+	if query.Category != nil {
+		category := models.Category(*query.Category)
+
+		if !category.Valid() {
+			userError(c, errSearchInvalidCategory)
+			return opts, false
+		}
+
+		opts.Category = &category
+	}
+
+	if query.Creator != "" {
+		creatorId, err := snowflake.ParseBase58([]byte(query.Creator))
+
+		if ok := checkUserError(c, err, errUserInvalidId); !ok {
+			return opts, false
+		}
+
+		opts.Creator = models.Id(creatorId.Int64())
+	}
+
+	if query.Before != "" {
+		cursor, err := snowflake.ParseBase58([]byte(query.Before))
+
+		if ok := checkUserError(c, err, errFeedInvalidCursor); !ok {
+			return opts, false
+		}
+
+		opts.Before = models.Id(cursor.Int64())
+	}
+
+	return opts, true
+}
+
+func SearchVideos(c *gin.Context) {
+	var query SearchQuery
+
+	if ok := checkValidationError(c, c.ShouldBindQuery(&query)); !ok {
+		return
+	}
+
+	opts, ok := query.toOptions(c)
+	if !ok {
+		return
+	}
+
+	videos, err := models.SearchVideos(opts)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	videoInfos := make([]*VideoInfo, 0, len(videos))
+	for _, video := range videos {
+		videoInfo, err := getVideoInfo(&video)
+
+		if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+			return
+		}
+
+		videoInfos = append(videoInfos, videoInfo)
+	}
+
+	c.JSON(http.StatusOK, videoInfos)
+}
+
+type TagQuery struct {
+	Prefix string `form:"prefix" binding:"required,min=1"`
+}
+
+func GetTags(c *gin.Context) {
+	var query TagQuery
+
+	if ok := checkValidationError(c, c.ShouldBindQuery(&query)); !ok {
+		return
+	}
+
+	tags, err := models.SearchTagsByPrefix(query.Prefix)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+
+	c.JSON(http.StatusOK, names)
+}
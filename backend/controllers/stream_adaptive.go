@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"streamfox-backend/codec"
+	"streamfox-backend/models"
+	"streamfox-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errVideoRenditionNonExistent = errors.New("requested rendition does not exist for this video")
+
+// beginTranscode runs the adaptive bitrate ladder in the background so UploadVideo can respond
+// as soon as the thumbnail is ready, rather than making the uploader wait on ffmpeg.
+func beginTranscode(video *models.Video, videoDir string) {
+	go func() {
+		for _, rendition := range codec.DefaultLadder {
+			row := &models.VideoRendition{
+				VideoId:      video.Id,
+				Name:         rendition.Name,
+				Width:        int32(rendition.Width),
+				Height:       int32(rendition.Height),
+				VideoBitrate: int32(rendition.VideoBitrate),
+				AudioBitrate: int32(rendition.AudioBitrate),
+				State:        models.RENDITION_TRANSCODING,
+			}
+
+			if err := models.SaveRendition(row); err != nil {
+				log.Println("could not persist rendition progress:", err)
+				return
+			}
+		}
+
+		results := codec.Transcode(videoDir, codec.DefaultLadder)
+
+		renditions, fetchErr := models.FetchRenditions(video.Id)
+		if fetchErr != nil {
+			log.Println("could not reload renditions after transcode:", fetchErr)
+			return
+		}
+
+		resultByName := make(map[string]codec.RenditionResult, len(results))
+		for _, result := range results {
+			resultByName[result.Name] = result
+		}
+
+		anyComplete := false
+		for i := range renditions {
+			result := resultByName[renditions[i].Name]
+
+			if result.Err != nil {
+				log.Println("transcode failed for rendition", renditions[i].Name, "of video", video.IdSnowflake().Base58(), ":", result.Err)
+				renditions[i].State = models.RENDITION_FAILED
+			} else {
+				renditions[i].SegmentCount = int32(result.SegmentCount)
+				renditions[i].State = models.RENDITION_COMPLETE
+				anyComplete = true
+			}
+
+			if saveErr := models.SaveRendition(&renditions[i]); saveErr != nil {
+				log.Println("could not persist rendition state:", saveErr)
+			}
+		}
+
+		if !anyComplete {
+			log.Println("all renditions failed for video", video.IdSnowflake().Base58())
+			return
+		}
+
+		video.Status = models.COMPLETE
+		if err := video.Save(); err != nil {
+			log.Println("could not mark video complete after transcode:", err)
+		}
+	}()
+}
+
+func GetHlsMaster(c *gin.Context) {
+	video := getVideoParam(c)
+
+	renditions, err := models.FetchRenditions(video.Id)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	ladder := make([]codec.Rendition, 0, len(renditions))
+	for _, rendition := range renditions {
+		if rendition.State != models.RENDITION_COMPLETE {
+			continue
+		}
+
+		ladder = append(ladder, codec.Rendition{
+			Name:         rendition.Name,
+			Width:        int(rendition.Width),
+			Height:       int(rendition.Height),
+			VideoBitrate: int(rendition.VideoBitrate),
+			AudioBitrate: int(rendition.AudioBitrate),
+		})
+	}
+
+	c.String(http.StatusOK, codec.GenerateHlsMaster(ladder))
+}
+
+func getHlsVariant(c *gin.Context, video *models.Video, rendition string) {
+	row, err := models.FetchRendition(video.Id, rendition)
+
+	if ok := checkUserError(c, err, errVideoRenditionNonExistent); !ok {
+		return
+	}
+
+	c.String(http.StatusOK, codec.GenerateHlsVariant(int(row.SegmentCount)))
+}
+
+func streamSegmentAndCount(c *gin.Context, rendition string) {
+	user := getUserParam(c)
+	video := getVideoParam(c)
+	dataRoot := utils.GetEnvVar(utils.DATA_ROOT)
+	videoDir := dataRoot + "/videos/" + video.IdSnowflake().Base58()
+
+	c.File(codec.SegmentPath(videoDir, rendition, c.Param("segment")))
+
+	bytesStreamed := int64(c.Writer.Size())
+
+	if bytesStreamed <= 0 {
+		return
+	}
+
+	err := video.ProcessStream(user, bytesStreamed)
+
+	recordError(c, err)
+}
+
+func GetHlsSegment(c *gin.Context) {
+	if c.Param("segment") == "variant.m3u8" {
+		getHlsVariant(c, getVideoParam(c), c.Param("rendition"))
+		return
+	}
+
+	streamSegmentAndCount(c, c.Param("rendition"))
+}
+
+func GetDashManifest(c *gin.Context) {
+	video := getVideoParam(c)
+
+	renditions, err := models.FetchRenditions(video.Id)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	ladder := make([]codec.Rendition, 0, len(renditions))
+	for _, rendition := range renditions {
+		if rendition.State != models.RENDITION_COMPLETE {
+			continue
+		}
+
+		ladder = append(ladder, codec.Rendition{
+			Name:         rendition.Name,
+			Width:        int(rendition.Width),
+			Height:       int(rendition.Height),
+			VideoBitrate: int(rendition.VideoBitrate),
+			AudioBitrate: int(rendition.AudioBitrate),
+		})
+	}
+
+	c.Data(http.StatusOK, "application/dash+xml", []byte(codec.GenerateDashManifest(ladder, video.DurationSecs)))
+}
+
+func GetDashSegment(c *gin.Context) {
+	streamSegmentAndCount(c, c.Param("rendition"))
+}
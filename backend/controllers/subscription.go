@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"streamfox-backend/models"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errUserInvalidId           = errors.New("user id is not a valid identifier")
+	errUserIdNonExistent       = errors.New("no user exists with the given id")
+	errUserCannotSubscribeSelf = errors.New("a user cannot subscribe to themselves")
+	errFeedInvalidCursor       = errors.New("feed cursor is not a valid identifier")
+)
+
+func getTargetUserParam(c *gin.Context) (*models.User, bool) {
+	userId, err := snowflake.ParseBase58([]byte(c.Param("id")))
+
+	if ok := checkUserError(c, err, errUserInvalidId); !ok {
+		return nil, false
+	}
+
+	user, err := models.FetchUser(models.Id(userId.Int64()))
+
+	if ok := checkUserError(c, err, errUserIdNonExistent); !ok {
+		return nil, false
+	}
+
+	return user, true
+}
+
+func Subscribe(c *gin.Context) {
+	subscriber := getUserParam(c)
+
+	creator, ok := getTargetUserParam(c)
+	if !ok {
+		return
+	}
+
+	if creator.Id == subscriber.Id {
+		userError(c, errUserCannotSubscribeSelf)
+		return
+	}
+
+	err := models.Subscribe(subscriber, creator)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func Unsubscribe(c *gin.Context) {
+	subscriber := getUserParam(c)
+
+	creator, ok := getTargetUserParam(c)
+	if !ok {
+		return
+	}
+
+	err := models.Unsubscribe(subscriber, creator)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func GetSubscribers(c *gin.Context) {
+	creator, ok := getTargetUserParam(c)
+	if !ok {
+		return
+	}
+
+	subscribers, err := models.FetchSubscribers(creator)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	subscriberInfos := make([]UserInfo, len(subscribers))
+	for i, subscriber := range subscribers {
+		subscriberInfos[i] = getUserInfo(&subscriber)
+	}
+
+	c.JSON(http.StatusOK, subscriberInfos)
+}
+
+type FeedQuery struct {
+	Before    string `form:"before"`
+	Unwatched bool   `form:"unwatched"`
+}
+
+func GetFeed(c *gin.Context) {
+	var query FeedQuery
+
+	if ok := checkValidationError(c, c.ShouldBindQuery(&query)); !ok {
+		return
+	}
+
+	var before models.Id
+	if query.Before != "" {
+		cursor, err := snowflake.ParseBase58([]byte(query.Before))
+
+		if ok := checkUserError(c, err, errFeedInvalidCursor); !ok {
+			return
+		}
+
+		before = models.Id(cursor.Int64())
+	}
+
+	user := getUserParam(c)
+	videos, err := models.FetchFeed(user, before, query.Unwatched)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	videoInfos := make([]*VideoInfo, 0, len(videos))
+	for _, video := range videos {
+		videoInfo, err := getVideoInfo(&video)
+
+		if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+			return
+		}
+
+		videoInfos = append(videoInfos, videoInfo)
+	}
+
+	c.JSON(http.StatusOK, videoInfos)
+}
+
+func GetUnwatchedFeedCount(c *gin.Context) {
+	user := getUserParam(c)
+
+	count, err := models.CountUnwatchedFeed(user)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, count)
+}
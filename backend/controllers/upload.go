@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"streamfox-backend/codec"
+	"streamfox-backend/models"
+	"streamfox-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errVideoUploadNotInitialized = errors.New("upload has not been initialized for this video")
+	errVideoInvalidChunkIndex    = errors.New("chunk index is missing or not a number")
+)
+
+type UploadInitInfo struct {
+	Filename       string `json:"filename"        binding:"required,min=1,max=256"`
+	TotalSizeBytes int64  `json:"total_size_bytes" binding:"required,min=1"`
+}
+
+type UploadInitResult struct {
+	UploadId string `json:"upload_id"`
+}
+
+func InitUpload(c *gin.Context) {
+	var init UploadInitInfo
+
+	if ok := checkValidationError(c, c.ShouldBindJSON(&init)); !ok {
+		return
+	}
+
+	video := getVideoParam(c)
+
+	if video.Status > models.UPLOADING_PARTIAL {
+		userError(c, errVideoCannotOverwrite)
+		return
+	}
+
+	dataRoot := utils.GetEnvVar(utils.DATA_ROOT)
+	partsDir := fmt.Sprintf("%s/videos/%s/parts", dataRoot, video.IdSnowflake().Base58())
+	err := os.MkdirAll(partsDir, os.ModePerm)
+
+	if ok := checkServerError(c, err, errGenericFileIo); !ok {
+		return
+	}
+
+	_, err = models.InitUpload(video, init.Filename, init.TotalSizeBytes)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	c.JSON(http.StatusCreated, UploadInitResult{UploadId: video.IdSnowflake().Base58()})
+}
+
+func chunkPath(dataRoot string, video *models.Video, index int32) string {
+	return fmt.Sprintf("%s/videos/%s/parts/%d", dataRoot, video.IdSnowflake().Base58(), index)
+}
+
+func UploadChunk(c *gin.Context) {
+	video := getVideoParam(c)
+
+	if video.Status != models.UPLOADING_PARTIAL {
+		userError(c, errVideoUploadNotInitialized)
+		return
+	}
+
+	index, err := strconv.ParseInt(c.GetHeader("Chunk-Index"), 10, 32)
+
+	if err != nil {
+		userError(c, errVideoInvalidChunkIndex)
+		return
+	}
+
+	dataRoot := utils.GetEnvVar(utils.DATA_ROOT)
+	path := chunkPath(dataRoot, video, int32(index))
+
+	file, err := os.Create(path)
+
+	if ok := checkServerError(c, err, errGenericFileIo); !ok {
+		return
+	}
+
+	written, err := io.Copy(file, c.Request.Body)
+
+	if ok := checkServerError(c, err, errGenericFileIo); !ok {
+		file.Close()
+		os.Remove(path)
+		return
+	}
+
+	err = file.Close()
+
+	if ok := checkServerError(c, err, errGenericFileIo); !ok {
+		os.Remove(path)
+		return
+	}
+
+	err = models.SaveChunk(video.Id, int32(index), written)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+type UploadStatusInfo struct {
+	TotalSizeBytes  int64   `json:"total_size_bytes"`
+	ReceivedIndices []int32 `json:"received_indices"`
+}
+
+func GetUploadStatus(c *gin.Context) {
+	video := getVideoParam(c)
+
+	upload, err := models.FetchUpload(video.Id)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	indices, err := models.FetchChunkIndices(video.Id)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, UploadStatusInfo{
+		TotalSizeBytes:  upload.TotalSizeBytes,
+		ReceivedIndices: indices,
+	})
+}
+
+func concatChunks(dataRoot string, video *models.Video, indices []int32) (string, error) {
+	videoDir := fmt.Sprintf("%s/videos/%s", dataRoot, video.IdSnowflake().Base58())
+	filepath := fmt.Sprintf("%s/video", videoDir)
+
+	file, err := os.Create(filepath)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	for _, index := range indices {
+		part, err := os.Open(chunkPath(dataRoot, video, index))
+
+		if err != nil {
+			return "", err
+		}
+
+		_, err = io.Copy(file, part)
+		part.Close()
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return filepath, nil
+}
+
+func FinalizeUpload(c *gin.Context) {
+	video := getVideoParam(c)
+
+	if video.Status != models.UPLOADING_PARTIAL {
+		userError(c, errVideoUploadNotInitialized)
+		return
+	}
+
+	upload, err := models.FetchUpload(video.Id)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	chunks, err := models.FetchChunks(video.Id)
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	if !models.UploadIsComplete(upload, chunks) {
+		userError(c, errVideoUploadIncomplete)
+		return
+	}
+
+	indices := make([]int32, len(chunks))
+	for i, chunk := range chunks {
+		indices[i] = chunk.Index
+	}
+
+	dataRoot := utils.GetEnvVar(utils.DATA_ROOT)
+	filepath, err := concatChunks(dataRoot, video, indices)
+
+	if ok := checkServerError(c, err, errGenericFileIo); !ok {
+		return
+	}
+
+	videoDir := fmt.Sprintf("%s/videos/%s", dataRoot, video.IdSnowflake().Base58())
+
+	probe, err := codec.Probe(filepath)
+
+	if err != nil {
+		os.Remove(filepath)
+
+		if errors.Is(err, codec.ErrInvalidVideoType) {
+			userError(c, errVideoInvalidFormat)
+		} else {
+			serverError(c, err, errVideoProbe)
+		}
+		return
+	}
+
+	info, err := os.Stat(filepath)
+
+	if ok := checkServerError(c, err, errVideoGetSize); !ok {
+		return
+	}
+
+	video.MimeType = probe.MimeType
+	video.DurationSecs = probe.DurationSecs
+	video.SizeBytes = info.Size()
+	video.Status = models.PROCESSING
+
+	err = codec.GenerateThumbnail(videoDir)
+
+	if ok := checkServerError(c, err, errGenericFileIo); !ok {
+		return
+	}
+
+	video.Status = models.TRANSCODING
+	err = video.Save()
+
+	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+		return
+	}
+
+	err = models.DeleteChunks(video.Id)
+	recordError(c, err)
+
+	err = models.DeleteUpload(video.Id)
+	recordError(c, err)
+
+	err = os.RemoveAll(fmt.Sprintf("%s/parts", videoDir))
+	recordError(c, err)
+
+	beginTranscode(video, videoDir)
+
+	c.Status(http.StatusNoContent)
+}
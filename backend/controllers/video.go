@@ -102,6 +102,8 @@ type VideoUpdateInfo struct {
 	Name        string             `json:"name"        binding:"required,min=2,max=256"`
 	Description *string            `json:"description" binding:"required"`
 	Visibility  *models.Visibility `json:"visibility"  binding:"required,min=0,max=2"`
+	Category    *int32             `json:"category"`
+	Tags        []string           `json:"tags"`
 }
 
 func UpdateVideo(c *gin.Context) {
@@ -111,6 +113,16 @@ func UpdateVideo(c *gin.Context) {
 		return
 	}
 
+	var category models.Category
+	if update.Category != nil {
+		category = models.Category(*update.Category)
+
+		if !category.Valid() {
+			userError(c, errSearchInvalidCategory)
+			return
+		}
+	}
+
 	video := getVideoParam(c)
 	video.Name = update.Name
 	video.Description = *update.Description
@@ -121,6 +133,22 @@ func UpdateVideo(c *gin.Context) {
 		return
 	}
 
+	if update.Category != nil {
+		err = models.SetVideoCategory(video.Id, category)
+
+		if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+			return
+		}
+	}
+
+	if update.Tags != nil {
+		err = models.SetVideoTags(video.Id, update.Tags)
+
+		if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
+			return
+		}
+	}
+
 	c.Status(http.StatusNoContent)
 }
 
@@ -195,7 +223,8 @@ func UploadVideo(c *gin.Context) {
 		return
 	}
 
-	video.Status = models.COMPLETE
+	video.Status = models.TRANSCODING
+	beginTranscode(video, videoDir)
 
 	c.Status(http.StatusNoContent)
 }
@@ -233,7 +262,18 @@ func getVideoInfo(video *models.Video) (*VideoInfo, error) {
 }
 
 func GetVideos(c *gin.Context) {
-	videos, err := models.FetchAllVideos()
+	var query SearchQuery
+
+	if ok := checkValidationError(c, c.ShouldBindQuery(&query)); !ok {
+		return
+	}
+
+	opts, ok := query.toOptions(c)
+	if !ok {
+		return
+	}
+
+	videos, err := models.SearchVideos(opts)
 
 	if ok := checkServerError(c, err, errGenericDatabaseIo); !ok {
 		return
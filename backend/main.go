@@ -21,5 +21,27 @@ func main() {
 	api.Use(middleware.JwtAuthMiddleware())
 	api.GET("/user", controllers.GetUser)
 
+	api.POST("/users/:id/subscribe", controllers.Subscribe)
+	api.DELETE("/users/:id/subscribe", controllers.Unsubscribe)
+	api.GET("/users/:id/subscribers", controllers.GetSubscribers)
+	api.GET("/feed", controllers.GetFeed)
+	api.GET("/feed/unwatched/count", controllers.GetUnwatchedFeedCount)
+
+	api.GET("/videos/search", controllers.SearchVideos)
+	api.GET("/tags", controllers.GetTags)
+	api.POST("/videos/import", controllers.ImportVideo)
+
+	videos := api.Group("/videos/:id")
+	videos.Use(controllers.ExtractVideoMiddleware)
+	videos.POST("/upload/init", controllers.EnsureIsOwnerMiddleware, controllers.InitUpload)
+	videos.PUT("/upload/chunk", controllers.EnsureIsOwnerMiddleware, controllers.UploadChunk)
+	videos.GET("/upload/status", controllers.EnsureIsOwnerMiddleware, controllers.GetUploadStatus)
+	videos.POST("/upload/finalize", controllers.EnsureIsOwnerMiddleware, controllers.FinalizeUpload)
+	videos.GET("/hls/master.m3u8", controllers.EnsureVisibleVideoMiddleware, controllers.GetHlsMaster)
+	videos.GET("/hls/:rendition/:segment", controllers.EnsureVisibleVideoMiddleware, controllers.GetHlsSegment)
+	videos.GET("/dash/manifest.mpd", controllers.EnsureVisibleVideoMiddleware, controllers.GetDashManifest)
+	videos.GET("/dash/:rendition/:segment", controllers.EnsureVisibleVideoMiddleware, controllers.GetDashSegment)
+	videos.GET("/import", controllers.EnsureIsOwnerMiddleware, controllers.GetImportStatus)
+
 	router.Run(":5000")
 }
@@ -0,0 +1,65 @@
+package models
+
+type Category int32
+
+// Category mirrors the standard YouTube-style taxonomy so familiar buckets can be reused as
+// search/browse filters without inventing a bespoke classification scheme.
+const (
+	FILM_AND_ANIMATION Category = iota
+	MUSIC
+	GAMING
+	EDUCATION
+	NEWS_AND_POLITICS
+	COMEDY
+	SPORTS
+	SCIENCE_AND_TECHNOLOGY
+	HOWTO_AND_STYLE
+	TRAVEL_AND_EVENTS
+)
+
+var categoryNames = map[Category]string{
+	FILM_AND_ANIMATION:     "Film & Animation",
+	MUSIC:                  "Music",
+	GAMING:                 "Gaming",
+	EDUCATION:              "Education",
+	NEWS_AND_POLITICS:      "News & Politics",
+	COMEDY:                 "Comedy",
+	SPORTS:                 "Sports",
+	SCIENCE_AND_TECHNOLOGY: "Science & Technology",
+	HOWTO_AND_STYLE:        "Howto & Style",
+	TRAVEL_AND_EVENTS:      "Travel & Events",
+}
+
+func (category Category) Name() string {
+	return categoryNames[category]
+}
+
+func (category Category) Valid() bool {
+	_, ok := categoryNames[category]
+	return ok
+}
+
+// VideoCategory associates a Video with its Category. It is kept as a side table rather than a
+// column on Video so every category can carry the full name lookup without a migration of the
+// core video table.
+type VideoCategory struct {
+	VideoId Id `gorm:"primaryKey; autoIncrement:false"`
+	Video   Video
+
+	Category Category `gorm:"not null"`
+}
+
+func SetVideoCategory(videoId Id, category Category) error {
+	return db.Save(&VideoCategory{VideoId: videoId, Category: category}).Error
+}
+
+func FetchVideoCategory(videoId Id) (*Category, error) {
+	row := &VideoCategory{}
+	err := db.First(row, videoId).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &row.Category, nil
+}
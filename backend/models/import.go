@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type ImportState int32
+
+const (
+	IMPORT_PENDING ImportState = iota
+	IMPORT_FETCHING_METADATA
+	IMPORT_DOWNLOADING
+	IMPORT_COMPLETE
+	IMPORT_FAILED
+)
+
+type VideoImport struct {
+	VideoId Id `gorm:"primaryKey; autoIncrement:false"`
+	Video   Video
+
+	SourceUrl string      `gorm:"not null"`
+	State     ImportState `gorm:"not null"`
+
+	ErrorMessage  string
+	RetryCount    int32
+	LastAttemptAt time.Time
+
+	RowMetadata
+}
+
+func SaveImport(videoImport *VideoImport) error {
+	return db.Save(videoImport).Error
+}
+
+func FetchImport(videoId Id) (*VideoImport, error) {
+	videoImport := &VideoImport{}
+	err := db.First(videoImport, videoId).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return videoImport, nil
+}
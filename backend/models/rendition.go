@@ -0,0 +1,61 @@
+package models
+
+type RenditionState int32
+
+const (
+	RENDITION_PENDING RenditionState = iota
+	RENDITION_TRANSCODING
+	RENDITION_COMPLETE
+	RENDITION_FAILED
+)
+
+type VideoRendition struct {
+	VideoId Id     `gorm:"primaryKey; autoIncrement:false"`
+	Name    string `gorm:"primaryKey"`
+
+	Width        int32
+	Height       int32
+	VideoBitrate int32
+	AudioBitrate int32
+	SegmentCount int32
+
+	State RenditionState `gorm:"not null"`
+
+	RowMetadata
+}
+
+func SaveRendition(rendition *VideoRendition) error {
+	return db.Save(rendition).Error
+}
+
+func FetchRenditions(videoId Id) ([]VideoRendition, error) {
+	var renditions []VideoRendition
+	err := db.Where(VideoRendition{VideoId: videoId}).Find(&renditions).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return renditions, nil
+}
+
+func FetchRendition(videoId Id, name string) (*VideoRendition, error) {
+	rendition := &VideoRendition{}
+	err := db.Where(VideoRendition{VideoId: videoId, Name: name}).First(rendition).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rendition, nil
+}
+
+func AllRenditionsComplete(renditions []VideoRendition) bool {
+	for _, rendition := range renditions {
+		if rendition.State != RENDITION_COMPLETE {
+			return false
+		}
+	}
+
+	return len(renditions) > 0
+}
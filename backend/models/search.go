@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type SearchSort string
+
+// SORT_VIEWS and SORT_LIKES are not implemented: there is no denormalized counter column to sort
+// on (views are counted per-request via CountViews, and there is no like system at all in this
+// revision), so they are rejected by Valid() the same as any other unrecognised value rather than
+// being accepted and silently reordered by something that doesn't exist.
+const (
+	SORT_RECENT SearchSort = "recent"
+	SORT_VIEWS  SearchSort = "views"
+	SORT_LIKES  SearchSort = "likes"
+)
+
+func (sort SearchSort) Valid() bool {
+	return sort == SORT_RECENT
+}
+
+const SearchPageSize = 20
+
+type SearchOptions struct {
+	Query           string
+	Category        *Category
+	Tag             string
+	Creator         Id
+	MinDurationSecs int32
+	MaxDurationSecs int32
+	Sort            SearchSort
+	Before          Id
+}
+
+// SearchVideos combines Postgres full-text search over name/description with category and tag
+// filters, ordered most-recent-first (the only implemented sort, see SearchSort.Valid).
+func SearchVideos(opts SearchOptions) ([]Video, error) {
+	query := db.Model(&Video{}).Where(Video{Status: COMPLETE})
+
+	if opts.Query != "" {
+		query = query.Where("search_vector @@ plainto_tsquery('english', ?)", opts.Query)
+	}
+
+	if opts.Category != nil {
+		query = query.Where("id IN (SELECT video_id FROM video_categories WHERE category = ?)", *opts.Category)
+	}
+
+	if opts.Tag != "" {
+		tag, err := FetchTagIdByName(opts.Tag)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []Video{}, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.Where("id IN (SELECT video_id FROM video_tags WHERE tag_id = ?)", tag.Id)
+	}
+
+	if opts.Creator != 0 {
+		query = query.Where(Video{CreatorId: opts.Creator})
+	}
+
+	if opts.MinDurationSecs > 0 {
+		query = query.Where("duration_secs >= ?", opts.MinDurationSecs)
+	}
+
+	if opts.MaxDurationSecs > 0 {
+		query = query.Where("duration_secs <= ?", opts.MaxDurationSecs)
+	}
+
+	if opts.Before != 0 {
+		query = query.Where("id < ?", opts.Before)
+	}
+
+	var videos []Video
+	err := query.Order("id desc").Limit(SearchPageSize).Find(&videos).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return videos, nil
+}
@@ -39,6 +39,19 @@ func Setup() {
 
 	DATABASE.AutoMigrate(&User{})
 	DATABASE.AutoMigrate(&Video{})
+	DATABASE.AutoMigrate(&VideoUpload{})
+	DATABASE.AutoMigrate(&VideoChunk{})
+	DATABASE.AutoMigrate(&VideoRendition{})
+	DATABASE.AutoMigrate(&Subscription{})
+	DATABASE.AutoMigrate(&VideoCategory{})
+	DATABASE.AutoMigrate(&Tag{})
+	DATABASE.AutoMigrate(&VideoTag{})
+	DATABASE.AutoMigrate(&VideoImport{})
+	DATABASE.AutoMigrate(&WatchHistory{})
+
+	DATABASE.Exec(`ALTER TABLE videos ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('english', coalesce(name, '') || ' ' || coalesce(description, ''))) STORED`)
+	DATABASE.Exec(`CREATE INDEX IF NOT EXISTS videos_search_vector_idx ON videos USING GIN (search_vector)`)
 
 	ID_GENERATOR, err = snowflake.NewNode(1)
 
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Subscription struct {
+	SubscriberId Id `gorm:"primaryKey; autoIncrement:false"`
+	Subscriber   User
+
+	CreatorId Id `gorm:"primaryKey; autoIncrement:false"`
+	Creator   User
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func Subscribe(subscriber *User, creator *User) error {
+	return db.Where(Subscription{SubscriberId: subscriber.Id, CreatorId: creator.Id}).
+		Attrs(Subscription{CreatedAt: time.Now()}).
+		FirstOrCreate(&Subscription{}).
+		Error
+}
+
+func Unsubscribe(subscriber *User, creator *User) error {
+	return db.Where(Subscription{SubscriberId: subscriber.Id, CreatorId: creator.Id}).
+		Delete(&Subscription{}).
+		Error
+}
+
+func IsSubscribedTo(subscriber *User, creator *User) (bool, error) {
+	var count int64
+	err := db.Model(&Subscription{}).
+		Where(Subscription{SubscriberId: subscriber.Id, CreatorId: creator.Id}).
+		Count(&count).
+		Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func FetchSubscribers(creator *User) ([]User, error) {
+	var subscriptions []Subscription
+	err := db.Where(Subscription{CreatorId: creator.Id}).
+		Preload("Subscriber").
+		Find(&subscriptions).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers := make([]User, len(subscriptions))
+	for i, subscription := range subscriptions {
+		subscribers[i] = subscription.Subscriber
+	}
+
+	return subscribers, nil
+}
+
+func fetchSubscribedCreatorIds(subscriber *User) ([]Id, error) {
+	var subscriptions []Subscription
+	err := db.Where(Subscription{SubscriberId: subscriber.Id}).Find(&subscriptions).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	creatorIds := make([]Id, len(subscriptions))
+	for i, subscription := range subscriptions {
+		creatorIds[i] = subscription.CreatorId
+	}
+
+	return creatorIds, nil
+}
+
+// watchedClause matches WatchHistory rather than the live Watch session: Watch only tracks one
+// in-progress video per user and is overwritten whenever they switch, so it can't answer "has
+// this user watched this video" for anything other than whatever they have open right now.
+// WatchHistory is written when the user starts a different video and the session they leave had
+// crossed WatchPercentageRequired (see hasMetWatchThreshold/recordWatchHistory in watch.go),
+// giving durable per-video history instead. The video of the session still open is not in it yet.
+const watchedClause = `EXISTS (
+	SELECT 1 FROM watch_histories
+	WHERE watch_histories.video_id = videos.id
+	AND watch_histories.user_id = ?
+)`
+
+func feedQuery(subscriber *User, unwatchedOnly bool) (*gorm.DB, error) {
+	creatorIds, err := fetchSubscribedCreatorIds(subscriber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Model(&Video{}).
+		Where("creator_id IN ?", creatorIds).
+		Where(Video{Status: COMPLETE})
+
+	if unwatchedOnly {
+		query = query.Where("NOT "+watchedClause, subscriber.Id)
+	}
+
+	return query, nil
+}
+
+const FeedPageSize = 20
+
+func FetchFeed(subscriber *User, before Id, unwatchedOnly bool) ([]Video, error) {
+	query, err := feedQuery(subscriber, unwatchedOnly)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if before != 0 {
+		query = query.Where("id < ?", before)
+	}
+
+	var videos []Video
+	err = query.Order("id desc").Limit(FeedPageSize).Find(&videos).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return videos, nil
+}
+
+func CountUnwatchedFeed(subscriber *User) (int64, error) {
+	query, err := feedQuery(subscriber, true)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = query.Count(&count).Error
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,92 @@
+package models
+
+type Tag struct {
+	Id Id `gorm:"primaryKey; autoIncrement:false"`
+
+	Name string `gorm:"not null; uniqueIndex"`
+}
+
+type VideoTag struct {
+	VideoId Id `gorm:"primaryKey; autoIncrement:false"`
+	Video   Video
+
+	TagId Id `gorm:"primaryKey; autoIncrement:false"`
+	Tag   Tag
+}
+
+func findOrCreateTag(name string) (*Tag, error) {
+	tag := &Tag{}
+	err := db.Where(Tag{Name: name}).
+		Attrs(Tag{Id: NewId()}).
+		FirstOrCreate(tag).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// SetVideoTags replaces every tag currently attached to videoId with the given tag names.
+func SetVideoTags(videoId Id, names []string) error {
+	err := db.Where(VideoTag{VideoId: videoId}).Delete(&VideoTag{}).Error
+
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		tag, err := findOrCreateTag(name)
+
+		if err != nil {
+			return err
+		}
+
+		err = db.Save(&VideoTag{VideoId: videoId, TagId: tag.Id}).Error
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func FetchVideoTags(videoId Id) ([]Tag, error) {
+	var videoTags []VideoTag
+	err := db.Where(VideoTag{VideoId: videoId}).Preload("Tag").Find(&videoTags).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]Tag, len(videoTags))
+	for i, videoTag := range videoTags {
+		tags[i] = videoTag.Tag
+	}
+
+	return tags, nil
+}
+
+func FetchTagIdByName(name string) (*Tag, error) {
+	tag := &Tag{}
+	err := db.Where(Tag{Name: name}).First(tag).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func SearchTagsByPrefix(prefix string) ([]Tag, error) {
+	var tags []Tag
+	err := db.Where("name ILIKE ?", prefix+"%").Order("name").Limit(20).Find(&tags).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,111 @@
+package models
+
+type VideoUpload struct {
+	VideoId Id `gorm:"primaryKey; autoIncrement:false"`
+	Video   Video
+
+	Filename       string `gorm:"not null"`
+	TotalSizeBytes int64  `gorm:"not null"`
+
+	RowMetadata
+}
+
+type VideoChunk struct {
+	VideoId Id    `gorm:"primaryKey; autoIncrement:false"`
+	Index   int32 `gorm:"primaryKey; autoIncrement:false"`
+
+	SizeBytes int64 `gorm:"not null"`
+
+	RowMetadata
+}
+
+func InitUpload(video *Video, filename string, totalSizeBytes int64) (*VideoUpload, error) {
+	upload := &VideoUpload{
+		VideoId:        video.Id,
+		Filename:       filename,
+		TotalSizeBytes: totalSizeBytes,
+	}
+
+	err := db.Save(upload).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	video.Status = UPLOADING_PARTIAL
+	err = video.Save()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func FetchUpload(videoId Id) (*VideoUpload, error) {
+	upload := &VideoUpload{}
+	err := db.First(upload, videoId).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func SaveChunk(videoId Id, index int32, sizeBytes int64) error {
+	return db.Save(&VideoChunk{
+		VideoId:   videoId,
+		Index:     index,
+		SizeBytes: sizeBytes,
+	}).Error
+}
+
+func FetchChunks(videoId Id) ([]VideoChunk, error) {
+	var chunks []VideoChunk
+	err := db.Where(VideoChunk{VideoId: videoId}).Order("index").Find(&chunks).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return chunks, nil
+}
+
+func FetchChunkIndices(videoId Id) ([]int32, error) {
+	chunks, err := FetchChunks(videoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	indices := make([]int32, len(chunks))
+	for i, chunk := range chunks {
+		indices[i] = chunk.Index
+	}
+
+	return indices, nil
+}
+
+func DeleteChunks(videoId Id) error {
+	return db.Where(VideoChunk{VideoId: videoId}).Delete(&VideoChunk{}).Error
+}
+
+func DeleteUpload(videoId Id) error {
+	return db.Delete(&VideoUpload{}, videoId).Error
+}
+
+// UploadIsComplete reports whether every byte of the declared total has been received,
+// assuming chunk indices are assigned contiguously starting from zero.
+func UploadIsComplete(upload *VideoUpload, chunks []VideoChunk) bool {
+	var receivedBytes int64
+	for i, chunk := range chunks {
+		if chunk.Index != int32(i) {
+			return false
+		}
+
+		receivedBytes += chunk.SizeBytes
+	}
+
+	return receivedBytes == upload.TotalSizeBytes
+}
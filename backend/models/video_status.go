@@ -0,0 +1,16 @@
+package models
+
+// Status models the lifecycle of a Video's backing media from creation through to playable
+// delivery. UPLOADING_PARTIAL and IMPORTING are alternative starting points to UPLOADING for
+// the resumable-chunked-upload and external-import flows respectively; both converge on
+// PROCESSING once their source file is fully on disk, then TRANSCODING before COMPLETE.
+type Status int32
+
+const (
+	UPLOADING Status = iota
+	UPLOADING_PARTIAL
+	IMPORTING
+	PROCESSING
+	TRANSCODING
+	COMPLETE
+)
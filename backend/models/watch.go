@@ -62,6 +62,20 @@ func watchFor(user *User, video *Video) (*Watch, error) {
 	}
 
 	if watch.VideoId != video.Id {
+		watched, err := hasMetWatchThreshold(watch)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if watched {
+			err = recordWatchHistory(watch.UserId, watch.VideoId)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		watch.VideoId = video.Id
 		watch.ViewId = NewId()
 		watch.StartedAt = time.Now()
@@ -76,6 +90,44 @@ func watchFor(user *User, video *Video) (*Watch, error) {
 	return watch, nil
 }
 
+// hasMetWatchThreshold reports whether watch has already crossed the completion threshold for
+// its video, using the same condition as calculateWatchConditions but evaluated directly against
+// the database so callers don't need to load the full Video row first.
+func hasMetWatchThreshold(watch *Watch) (bool, error) {
+	var count int64
+	err := db.Model(&Video{}).
+		Where("id = ?", watch.VideoId).
+		Where(
+			"? >= CEIL(size_bytes * ?) AND EXTRACT(EPOCH FROM (? - ?)) * 1000 >= CEIL(duration_secs * 1000 * ?)",
+			*watch.BytesStreamed, WatchPercentageRequired, time.Now(), watch.StartedAt, WatchPercentageRequired,
+		).
+		Count(&count).
+		Error
+
+	return count > 0, err
+}
+
+// WatchHistory records that a user has, at some point, watched a video past the completion
+// threshold - unlike Watch, which only tracks a single in-progress session per user and is
+// overwritten whenever they switch videos, WatchHistory accumulates one durable row per
+// (user, video) pair so "has this user watched this video" can be answered after the fact.
+type WatchHistory struct {
+	UserId Id `gorm:"primaryKey; autoIncrement:false"`
+	User   User
+
+	VideoId Id `gorm:"primaryKey; autoIncrement:false"`
+	Video   Video
+
+	CompletedAt time.Time `gorm:"not null"`
+}
+
+func recordWatchHistory(userId Id, videoId Id) error {
+	return db.Where(WatchHistory{UserId: userId, VideoId: videoId}).
+		Attrs(WatchHistory{CompletedAt: time.Now()}).
+		FirstOrCreate(&WatchHistory{}).
+		Error
+}
+
 func watchOrNil(user *User) (*Watch, error) {
 	watch := &Watch{}
 	err := db.First(watch, user.Id).Error